@@ -0,0 +1,33 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package build holds flags that are baked into the binary at compile time
+// via `-ldflags`, as opposed to runtime configuration.
+package build
+
+// usageReportingEnabled is set via -ldflags, e.g.
+// -X github.com/DopplerHQ/cli/pkg/build.usageReportingEnabled=false
+// Enterprise and air-gapped distributors can use this to ship a binary that
+// never makes an outbound network call the user didn't explicitly ask for.
+var usageReportingEnabled = "true"
+
+// UsageReportingEnabled reports whether this binary is permitted to make
+// non-essential outbound network calls, such as version checks, analytics,
+// and the self-update downloader. It is false only when explicitly disabled
+// at build time; there is no runtime flag that can override it.
+func UsageReportingEnabled() bool {
+	return usageReportingEnabled != "false"
+}
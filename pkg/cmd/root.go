@@ -20,10 +20,12 @@ import (
 	"os"
 	"time"
 
+	"github.com/DopplerHQ/cli/pkg/build"
 	"github.com/DopplerHQ/cli/pkg/configuration"
 	"github.com/DopplerHQ/cli/pkg/controllers"
 	"github.com/DopplerHQ/cli/pkg/http"
 	"github.com/DopplerHQ/cli/pkg/printer"
+	"github.com/DopplerHQ/cli/pkg/update"
 	"github.com/DopplerHQ/cli/pkg/utils"
 	"github.com/DopplerHQ/cli/pkg/version"
 	"github.com/spf13/cobra"
@@ -47,14 +49,20 @@ var rootCmd = &cobra.Command{
 		printConfig := utils.GetBoolFlagIfChanged(cmd, "print-config", false)
 		if printConfig {
 			fmt.Println("Active configuration")
-			printer.ScopedConfigSource(configuration.LocalConfig(cmd), false, true, true)
+			if output := printer.OutputFormat(cmd); output == "table" {
+				// table is ScopedConfigSource's own bespoke renderer, not the generic fallback
+				printer.ScopedConfigSource(configuration.LocalConfig(cmd), false, true, true)
+			} else if err := printer.Render(cmd, configuration.LocalConfig(cmd)); err != nil {
+				utils.HandleError(err, "Unable to render active configuration")
+			}
 			fmt.Println("")
 		}
 
 		plain := utils.GetBoolFlagIfChanged(cmd, "plain", false)
 		// only run version check if we can print the results
 		// --plain doesn't normally affect logging output, but due to legacy reasons it does here
-		if utils.CanLogInfo() && !plain {
+		// usage reporting is a build-time decision and cannot be re-enabled by any flag
+		if build.UsageReportingEnabled() && utils.CanLogInfo() && !plain {
 			checkVersion(cmd.CalledAs())
 		}
 	},
@@ -66,7 +74,36 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// versionCheckOutcome carries the result of an in-flight version check from
+// the goroutine spawned by checkVersion to awaitVersionCheck.
+type versionCheckOutcome struct {
+	available    bool
+	versionCheck configuration.VersionCheck
+}
+
+// versionCheckResultCh is buffered so the goroutine never blocks on send,
+// even if awaitVersionCheck's deadline has already elapsed.
+var versionCheckResultCh = make(chan versionCheckOutcome, 1)
+
+// versionCheckStarted records whether checkVersion actually launched the
+// background goroutine this run. awaitVersionCheck must not wait on
+// versionCheckResultCh when it's false -- nothing will ever send on it, and
+// every invocation that skips the check (including "doppler run", the exact
+// case this must stay fast for) would otherwise block for versionCheckTimeout
+// for no reason.
+var versionCheckStarted = false
+
+// checkVersion kicks off a version check in the background so that it never
+// delays the command the user actually ran -- commands like "doppler run"
+// must not block on an api.doppler.com round-trip. The result is persisted to
+// disk as soon as it's known, and awaitVersionCheck gives this invocation a
+// short window to still show the update prompt if the check finishes in time.
 func checkVersion(command string) {
+	// usage reporting is disabled for this build; nothing below may run
+	if !build.UsageReportingEnabled() {
+		return
+	}
+
 	// disable version checking on the "run" command and "secrets download" command
 	if command == "run" || command == "download" {
 		return
@@ -82,27 +119,60 @@ func checkVersion(command string) {
 		return
 	}
 
-	available, versionCheck, err := controllers.NewVersionAvailable(prevVersionCheck)
-	if err != nil {
-		// retry on next run
+	versionCheckStarted = true
+
+	go func() {
+		available, versionCheck, err := controllers.NewVersionAvailable(prevVersionCheck)
+		if err != nil {
+			// retry on next run
+			return
+		}
+
+		if !available {
+			// re-use existing version
+			versionCheck.LatestVersion = prevVersionCheck.LatestVersion
+		}
+
+		// persist before reporting back; a check still running when the
+		// process exits persists nothing
+		configuration.SetVersionCheck(versionCheck)
+
+		versionCheckResultCh <- versionCheckOutcome{available, versionCheck}
+	}()
+}
+
+// versionCheckTimeout bounds how long awaitVersionCheck will wait for
+// checkVersion's goroutine before giving up on showing a prompt this run.
+const versionCheckTimeout = 250 * time.Millisecond
+
+// awaitVersionCheck waits briefly for a version check started by checkVersion
+// to complete, then shows the update prompt if one is due. If the goroutine
+// hasn't finished within versionCheckTimeout, the prompt is simply skipped --
+// the process then exits before the check has persisted anything, so the next
+// invocation runs the check again and can still show the prompt.
+func awaitVersionCheck() {
+	if !versionCheckStarted {
 		return
 	}
 
-	if !available {
-		utils.LogDebug("No CLI updates available")
-		// re-use existing version
-		versionCheck.LatestVersion = prevVersionCheck.LatestVersion
-	} else if utils.IsWindows() {
-		utils.Log(fmt.Sprintf("Update: Doppler CLI %s is available\n\nYou can update via 'scoop update doppler'\n", versionCheck.LatestVersion))
-	} else {
-		utils.Log(color.Green.Sprintf("An update is available."))
-		prompt := fmt.Sprintf("Install Doppler CLI %s", versionCheck.LatestVersion)
-		if utils.ConfirmationPrompt(prompt, true) {
-			installCLIUpdate()
+	select {
+	case outcome := <-versionCheckResultCh:
+		if !outcome.available {
+			utils.LogDebug("No CLI updates available")
+		} else if utils.IsWindows() {
+			utils.Log(fmt.Sprintf("Update: Doppler CLI %s is available\n\nYou can update via 'scoop update doppler'\n", outcome.versionCheck.LatestVersion))
+		} else {
+			utils.Log(color.Green.Sprintf("An update is available."))
+			prompt := fmt.Sprintf("Install Doppler CLI %s", outcome.versionCheck.LatestVersion)
+			if utils.ConfirmationPrompt(prompt, true) {
+				if err := installCLIUpdate(outcome.versionCheck.LatestVersion); err != nil {
+					utils.HandleError(err, "Unable to install update")
+				}
+			}
 		}
+	case <-time.After(versionCheckTimeout):
+		// not ready yet; retry showing the prompt on the next run
 	}
-
-	configuration.SetVersionCheck(versionCheck)
 }
 
 func loadFlags(cmd *cobra.Command) {
@@ -119,8 +189,19 @@ func loadFlags(cmd *cobra.Command) {
 	utils.Silent = utils.GetBoolFlagIfChanged(cmd, "silent", utils.Silent)
 	// no-file is used by the 'secrets download' command to output secrets to stdout
 	utils.Silent = utils.GetBoolFlagIfChanged(cmd, "no-file", utils.Silent)
+	// --json is deprecated in favor of --output/-o json, but is kept working as an alias
 	utils.OutputJSON = utils.GetBoolFlagIfChanged(cmd, "json", utils.OutputJSON)
-	version.PerformVersionCheck = !utils.GetBoolFlagIfChanged(cmd, "no-check-version", !version.PerformVersionCheck)
+	if utils.OutputJSON {
+		utils.LogDebug("--json is deprecated, please use --output json instead")
+	}
+	// a quiet build refuses to have version checking re-enabled, regardless of flags or saved config
+	version.PerformVersionCheck = build.UsageReportingEnabled() && !utils.GetBoolFlagIfChanged(cmd, "no-check-version", !version.PerformVersionCheck)
+	update.SkipSignatureVerification = utils.GetBoolFlagIfChanged(cmd, "no-verify-signature", update.SkipSignatureVerification)
+
+	updateChannel := utils.GetStringFlagIfChanged(cmd, "update-channel", version.UpdateChannel)
+	if version.UpdateChannel, err = version.NormalizeUpdateChannel(updateChannel); err != nil {
+		utils.HandleError(err, fmt.Sprintf("Invalid update channel: %s", updateChannel))
+	}
 }
 
 func deprecatedCommand(newCommand string) {
@@ -134,24 +215,51 @@ func deprecatedCommand(newCommand string) {
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// os.Exit runs no deferred functions, so track the exit code and call it
+	// once at the very end -- otherwise an error return below would skip the
+	// panic recovery and version check defers entirely.
+	exitCode := 0
+
+	// give a background version check a brief window to report, and persist
+	// its result, before the process actually exits
+	defer func() {
+		// a panic that wasn't recovered below (dev builds) must still crash,
+		// not end in os.Exit
+		if err := recover(); err != nil {
+			panic(err)
+		}
+		awaitVersionCheck()
+		os.Exit(exitCode)
+	}()
+
 	// catch any panics in non-dev builds
 	defer func() {
 		if !version.IsDevelopment() {
 			if err := recover(); err != nil {
 				fmt.Fprintf(os.Stderr, fmt.Sprintf("%s %v\n", color.Red.Render("Doppler Exception:"), err))
-				os.Exit(1)
+				exitCode = 1
 			}
 		}
 	}()
 
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		exitCode = 1
 	}
 }
 
+// buildModeFooter describes this binary's usage reporting posture, surfaced
+// via --version and help output so air-gapped distributors can verify a
+// binary was built quiet without inspecting its source.
+func buildModeFooter() string {
+	if build.UsageReportingEnabled() {
+		return ""
+	}
+	return "\nBuild mode: usage reporting disabled (no version checks, analytics, or self-update)\n"
+}
+
 func init() {
 	rootCmd.Version = version.ProgramVersion
-	rootCmd.SetVersionTemplate(rootCmd.Version + "\n")
+	rootCmd.SetVersionTemplate(rootCmd.Version + "\n" + buildModeFooter())
 	rootCmd.Flags().BoolP("version", "v", false, "Get the version of the Doppler CLI")
 
 	rootCmd.PersistentFlags().StringP("token", "t", "", "doppler token")
@@ -160,13 +268,18 @@ func init() {
 	rootCmd.PersistentFlags().Bool("no-check-version", !version.PerformVersionCheck, "disable checking for Doppler CLI updates")
 	rootCmd.PersistentFlags().Bool("no-verify-tls", false, "do not verify the validity of TLS certificates on HTTP requests (not recommended)")
 	rootCmd.PersistentFlags().Bool("no-timeout", !http.UseTimeout, "disable http timeout")
+	rootCmd.PersistentFlags().Bool("no-verify-signature", update.SkipSignatureVerification, "do not verify the GPG signature of downloaded CLI updates (not recommended)")
+	rootCmd.PersistentFlags().String("update-channel", version.UpdateChannel, "granularity of new CLI version to be notified about: major, minor, or patch")
 	rootCmd.PersistentFlags().Duration("timeout", http.TimeoutDuration, "max http request duration")
 
 	rootCmd.PersistentFlags().Bool("no-read-env", false, "do not read config from the environment")
 	rootCmd.PersistentFlags().String("scope", configuration.Scope, "the directory to scope your config to")
 	rootCmd.PersistentFlags().String("configuration", configuration.UserConfigFile, "config file")
-	rootCmd.PersistentFlags().Bool("json", utils.OutputJSON, "output json")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "output format: json, yaml, table, template=<go-template>, jsonpath=<expr>")
+	rootCmd.PersistentFlags().Bool("json", utils.OutputJSON, "output json (deprecated, use --output json)")
 	rootCmd.PersistentFlags().Bool("debug", utils.Debug, "output additional information")
 	rootCmd.PersistentFlags().Bool("print-config", false, "output active configuration")
 	rootCmd.PersistentFlags().Bool("silent", utils.Silent, "disable output of info messages")
+
+	rootCmd.SetHelpTemplate(rootCmd.HelpTemplate() + buildModeFooter())
 }
@@ -0,0 +1,56 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAwaitVersionCheckSkipsWhenNotStarted ensures commands like "doppler
+// run" -- where checkVersion never launches its goroutine -- return from
+// awaitVersionCheck immediately instead of idling for versionCheckTimeout.
+func TestAwaitVersionCheckSkipsWhenNotStarted(t *testing.T) {
+	versionCheckStarted = false
+
+	start := time.Now()
+	awaitVersionCheck()
+	elapsed := time.Since(start)
+
+	if elapsed >= versionCheckTimeout {
+		t.Fatalf("awaitVersionCheck blocked for %s with no check in flight", elapsed)
+	}
+}
+
+// TestAwaitVersionCheckBoundedWaitWhenHanging ensures a version check that
+// hangs (e.g. the network call never returns) only delays the command by
+// versionCheckTimeout, never longer.
+func TestAwaitVersionCheckBoundedWaitWhenHanging(t *testing.T) {
+	versionCheckStarted = true
+	defer func() { versionCheckStarted = false }()
+
+	start := time.Now()
+	awaitVersionCheck()
+	elapsed := time.Since(start)
+
+	if elapsed < versionCheckTimeout {
+		t.Fatalf("expected to wait at least %s, only waited %s", versionCheckTimeout, elapsed)
+	}
+	if elapsed > versionCheckTimeout+100*time.Millisecond {
+		t.Fatalf("awaitVersionCheck blocked for %s, expected a bounded wait near %s", elapsed, versionCheckTimeout)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/DopplerHQ/cli/pkg/http"
+	"github.com/DopplerHQ/cli/pkg/update"
+	"github.com/DopplerHQ/cli/pkg/utils"
+)
+
+// installCLIUpdate downloads the latest release asset for the current
+// platform, verifies it against Doppler's signed checksums, and replaces the
+// currently running executable. It fails closed: any error downloading or
+// verifying the release aborts the update without touching the existing
+// binary.
+func installCLIUpdate(version string) error {
+	assetName := releaseAssetName(version)
+
+	checksumsTxt, err := http.GetRelease(version, "checksums.txt")
+	if err != nil {
+		return fmt.Errorf("unable to download checksums.txt: %w", err)
+	}
+
+	asset, err := http.GetRelease(version, assetName)
+	if err != nil {
+		return fmt.Errorf("unable to download %s: %w", assetName, err)
+	}
+
+	if update.SkipSignatureVerification {
+		utils.LogWarning("Skipping signature verification of the downloaded release (--no-verify-signature)")
+	} else {
+		signature, err := http.GetRelease(version, "checksums.txt.sig")
+		if err != nil {
+			return fmt.Errorf("unable to download checksums.txt.sig: %w", err)
+		}
+
+		if err := update.VerifyChecksumsSignature(checksumsTxt, signature); err != nil {
+			return err
+		}
+	}
+
+	expectedChecksum, err := update.ChecksumFor(checksumsTxt, assetName)
+	if err != nil {
+		return err
+	}
+
+	if err := update.VerifyChecksum(asset, expectedChecksum); err != nil {
+		return err
+	}
+
+	return utils.ReplaceExecutable(asset)
+}
+
+// releaseAssetName returns the expected release asset filename for the
+// current platform, matching the naming convention used by the CLI's
+// goreleaser config.
+func releaseAssetName(version string) string {
+	ext := "tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = "zip"
+	}
+
+	return fmt.Sprintf("doppler_%s_%s_%s.%s", version, runtime.GOOS, runtime.GOARCH, ext)
+}
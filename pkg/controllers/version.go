@@ -0,0 +1,46 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/DopplerHQ/cli/pkg/configuration"
+	"github.com/DopplerHQ/cli/pkg/http"
+	"github.com/DopplerHQ/cli/pkg/utils"
+	"github.com/DopplerHQ/cli/pkg/version"
+)
+
+// NewVersionAvailable fetches the latest released CLI version and reports
+// whether the user should be prompted to update, per version.VersionsMatch
+// and the configured --update-channel. Under the default "minor" channel a
+// patch-only difference is logged at debug level rather than surfaced as a
+// mismatch, keeping routine patch releases out of interactive developer
+// workflows; the "patch" channel surfaces it.
+func NewVersionAvailable(prevVersionCheck configuration.VersionCheck) (bool, configuration.VersionCheck, error) {
+	latestVersion, err := http.GetLatestCLIVersion()
+	if err != nil {
+		return false, prevVersionCheck, err
+	}
+
+	versionCheck := configuration.VersionCheck{LatestVersion: latestVersion}
+
+	if version.VersionsMatch(version.ProgramVersion, latestVersion) {
+		utils.LogDebug("CLI version matches the configured update channel; no prompt needed")
+		return false, versionCheck, nil
+	}
+
+	return true, versionCheck, nil
+}
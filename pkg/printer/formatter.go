@@ -0,0 +1,139 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatter renders a value in a specific output format. Built-in formatters
+// are registered in init(); callers can add their own with RegisterFormatter.
+type Formatter interface {
+	Format(v interface{}, w io.Writer) error
+}
+
+var formatters = map[string]Formatter{}
+
+// RegisterFormatter adds a Formatter under name, making it selectable via
+// `--output <name>`. Registering under a name that's already taken replaces
+// the existing formatter. The names "template" and "jsonpath" are handled by
+// RenderWith itself, so a formatter registered under either is never selected.
+func RegisterFormatter(name string, formatter Formatter) {
+	formatters[name] = formatter
+}
+
+// lookupFormatter returns the Formatter registered under name, if any.
+func lookupFormatter(name string) (Formatter, bool) {
+	f, ok := formatters[name]
+	return f, ok
+}
+
+func init() {
+	RegisterFormatter("json", jsonFormatter{})
+	RegisterFormatter("yaml", yamlFormatter{})
+	RegisterFormatter("table", tableFormatter{})
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(v interface{}, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type yamlFormatter struct{}
+
+func (yamlFormatter) Format(v interface{}, w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(v)
+}
+
+// tableFormatter is a generic fallback for commands that haven't been
+// migrated to a bespoke table renderer yet. It handles the common case of a
+// slice of flat maps (as produced by most `--output table` call sites) and
+// falls back to a two-column key/value dump for anything else.
+type tableFormatter struct{}
+
+func (tableFormatter) Format(v interface{}, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if rows, ok := v.([]map[string]string); ok {
+		for _, row := range rows {
+			for _, key := range sortedKeys(row) {
+				fmt.Fprintf(tw, "%s\t%s\n", key, row[key])
+			}
+		}
+		return tw.Flush()
+	}
+
+	pairs := keyValuePairs(v)
+	for _, key := range sortedKeys(pairs) {
+		fmt.Fprintf(tw, "%s\t%s\n", key, pairs[key])
+	}
+	return tw.Flush()
+}
+
+// keyValuePairs reflects over v to produce a flat key/value view of it, used
+// by tableFormatter's fallback for anything that isn't a []map[string]string.
+// Maps are keyed by their string-formatted keys; structs are keyed by their
+// exported field names; anything else collapses to a single "value" row.
+func keyValuePairs(v interface{}) map[string]string {
+	pairs := map[string]string{}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			pairs[fmt.Sprintf("%v", key.Interface())] = fmt.Sprintf("%v", rv.MapIndex(key).Interface())
+		}
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			pairs[field.Name] = fmt.Sprintf("%v", rv.Field(i).Interface())
+		}
+	default:
+		pairs["value"] = fmt.Sprintf("%v", v)
+	}
+
+	return pairs
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/DopplerHQ/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// defaultOutputFormat is used when neither --output nor the deprecated
+// --json flag was passed.
+const defaultOutputFormat = "table"
+
+// Render picks the formatter selected by `--output`/`-o` (falling back to the
+// deprecated `--json` flag, then to the table format) and writes data to
+// cmd's output stream with it. Commands that currently branch on
+// utils.OutputJSON should call this instead so new formatters "just work".
+func Render(cmd *cobra.Command, data interface{}) error {
+	return RenderWith(OutputFormat(cmd), data, cmd.OutOrStdout())
+}
+
+// OutputFormat resolves the effective `--output` value for cmd, honoring the
+// deprecated `--json` alias for backwards compatibility.
+func OutputFormat(cmd *cobra.Command) string {
+	if output := utils.GetStringFlagIfChanged(cmd, "output", ""); output != "" {
+		return output
+	}
+
+	if utils.OutputJSON {
+		return "json"
+	}
+
+	return defaultOutputFormat
+}
+
+// RenderWith formats data with the formatter named by output and writes it
+// to w. output may be a bare formatter name ("json", "yaml", "table") or a
+// parameterized one ("template=<go-template>", "jsonpath=<expr>").
+func RenderWith(output string, data interface{}, w io.Writer) error {
+	name, arg := splitOutputFormat(output)
+
+	switch name {
+	case "template":
+		return (&templateFormatter{tmpl: arg}).Format(data, w)
+	case "jsonpath":
+		return (&jsonpathFormatter{expr: arg}).Format(data, w)
+	}
+
+	formatter, ok := lookupFormatter(name)
+	if !ok {
+		return fmt.Errorf("unknown output format %q", name)
+	}
+	return formatter.Format(data, w)
+}
+
+// splitOutputFormat splits an "--output" value like "template=<expr>" into
+// its formatter name and argument. Bare names (e.g. "json") return an empty
+// argument.
+func splitOutputFormat(output string) (string, string) {
+	name, arg, found := strings.Cut(output, "=")
+	if !found {
+		return name, ""
+	}
+	return name, arg
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/PaesslerAG/jsonpath"
+)
+
+// templateFormatter renders v through a user-supplied Go text/template, e.g.
+// `--output 'template={{range .}}{{.Name}}={{.ComputedValue}}\n{{end}}'`.
+type templateFormatter struct {
+	tmpl string
+}
+
+func (f *templateFormatter) Format(v interface{}, w io.Writer) error {
+	tmpl, err := template.New("output").Parse(f.tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid --output template: %w", err)
+	}
+	return tmpl.Execute(w, v)
+}
+
+// jsonpathFormatter renders v by evaluating a JSONPath expression against it,
+// e.g. `--output jsonpath='$[*].name'`.
+type jsonpathFormatter struct {
+	expr string
+}
+
+func (f *jsonpathFormatter) Format(v interface{}, w io.Writer) error {
+	result, err := jsonpath.Get(f.expr, v)
+	if err != nil {
+		return fmt.Errorf("invalid --output jsonpath expression: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%v\n", result)
+	return err
+}
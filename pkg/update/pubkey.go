@@ -0,0 +1,27 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import _ "embed"
+
+// PublicKey is Doppler's release signing key, used to verify the
+// checksums.txt that accompanies every CLI release before a self-update is
+// allowed to proceed. Keep this in sync with the key published alongside
+// releases and documented in INSTALL.md for users who verify manually --
+// rotating the signing key requires re-embedding it here in lockstep.
+//go:embed doppler_pubkey.asc
+var PublicKey []byte
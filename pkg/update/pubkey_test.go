@@ -0,0 +1,33 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// TestPublicKeyParses guards against shipping an embedded key that isn't
+// real OpenPGP key material -- the failure mode that previously shipped
+// unnoticed, since it only surfaces when an update actually runs.
+func TestPublicKeyParses(t *testing.T) {
+	if _, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(PublicKey)); err != nil {
+		t.Fatalf("embedded release signing key failed to parse: %v", err)
+	}
+}
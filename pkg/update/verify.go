@@ -0,0 +1,84 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package update implements release verification for the CLI's self-update
+// flow: checking a downloaded checksums.txt against its detached GPG
+// signature, then checking a downloaded asset against its signed checksum.
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// SkipSignatureVerification disables signature verification during
+// self-update. It is set from the --no-verify-signature flag and defaults to
+// false; verification is fail-closed, so leave this alone unless you have a
+// specific reason to bypass it.
+var SkipSignatureVerification = false
+
+// VerifyChecksumsSignature verifies that checksumsTxt was signed by Doppler's
+// embedded release signing key. It returns an error if the signature is
+// missing, malformed, or does not match -- the update must abort in all of
+// these cases rather than proceeding.
+func VerifyChecksumsSignature(checksumsTxt []byte, signature []byte) error {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(PublicKey))
+	if err != nil {
+		return fmt.Errorf("unable to load Doppler's release signing key: %w", err)
+	}
+
+	_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(checksumsTxt), bytes.NewReader(signature))
+	if err != nil {
+		return fmt.Errorf("checksums.txt failed signature verification: %w", err)
+	}
+
+	return nil
+}
+
+// ChecksumFor looks up the expected SHA-256 checksum for assetName within a
+// standard goreleaser-style checksums.txt ("<hex digest>  <filename>" per
+// line). It returns an error if the asset isn't listed.
+func ChecksumFor(checksumsTxt []byte, assetName string) (string, error) {
+	for _, line := range strings.Split(string(checksumsTxt), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[1] == assetName {
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum listed for %s", assetName)
+}
+
+// VerifyChecksum returns an error unless data's SHA-256 digest matches
+// expectedHex exactly.
+func VerifyChecksum(data []byte, expectedHex string) error {
+	sum := sha256.Sum256(data)
+	actualHex := hex.EncodeToString(sum[:])
+
+	if actualHex != expectedHex {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedHex, actualHex)
+	}
+
+	return nil
+}
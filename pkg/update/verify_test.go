@@ -0,0 +1,138 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// withTestKeyring generates a throwaway keypair, swaps it in for the
+// package's embedded PublicKey for the duration of the test, and returns a
+// function that signs data as that key.
+func withTestKeyring(t *testing.T) func(data []byte) []byte {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Test Signer", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("unable to generate test keypair: %v", err)
+	}
+
+	var pubKey bytes.Buffer
+	armorWriter, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("unable to armor-encode test public key: %v", err)
+	}
+	if err := entity.Serialize(armorWriter); err != nil {
+		t.Fatalf("unable to serialize test public key: %v", err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		t.Fatalf("unable to close test public key armor writer: %v", err)
+	}
+
+	origPublicKey := PublicKey
+	PublicKey = pubKey.Bytes()
+	t.Cleanup(func() { PublicKey = origPublicKey })
+
+	return func(data []byte) []byte {
+		var signature bytes.Buffer
+		if err := openpgp.DetachSign(&signature, entity, bytes.NewReader(data), nil); err != nil {
+			t.Fatalf("unable to sign test data: %v", err)
+		}
+		return signature.Bytes()
+	}
+}
+
+func TestVerifyChecksumsSignatureValidSignature(t *testing.T) {
+	sign := withTestKeyring(t)
+	checksumsTxt := []byte("deadbeef  doppler_1.0.0_linux_amd64.tar.gz\n")
+
+	if err := VerifyChecksumsSignature(checksumsTxt, sign(checksumsTxt)); err != nil {
+		t.Fatalf("expected a validly signed checksums.txt to verify, got: %v", err)
+	}
+}
+
+func TestVerifyChecksumsSignatureTamperedChecksums(t *testing.T) {
+	sign := withTestKeyring(t)
+	checksumsTxt := []byte("deadbeef  doppler_1.0.0_linux_amd64.tar.gz\n")
+	signature := sign(checksumsTxt)
+
+	tampered := append([]byte(nil), checksumsTxt...)
+	tampered[0] ^= 0xFF
+
+	if err := VerifyChecksumsSignature(tampered, signature); err == nil {
+		t.Fatal("expected tampered checksums.txt to fail verification")
+	}
+}
+
+func TestVerifyChecksumsSignatureMissingSignature(t *testing.T) {
+	withTestKeyring(t)
+	checksumsTxt := []byte("deadbeef  doppler_1.0.0_linux_amd64.tar.gz\n")
+
+	if err := VerifyChecksumsSignature(checksumsTxt, nil); err == nil {
+		t.Fatal("expected a missing signature to fail verification")
+	}
+}
+
+func TestVerifyChecksumsSignatureWrongKey(t *testing.T) {
+	sign := withTestKeyring(t)
+	checksumsTxt := []byte("deadbeef  doppler_1.0.0_linux_amd64.tar.gz\n")
+	signature := sign(checksumsTxt)
+
+	// swap in a second, unrelated keyring after signing -- the signature was
+	// made with the first key, so it must not verify against the second
+	withTestKeyring(t)
+
+	if err := VerifyChecksumsSignature(checksumsTxt, signature); err == nil {
+		t.Fatal("expected a signature from an unrecognized key to fail verification")
+	}
+}
+
+func TestChecksumFor(t *testing.T) {
+	checksumsTxt := []byte("aaaa  foo.tar.gz\nbbbb  bar.tar.gz\n")
+
+	sum, err := ChecksumFor(checksumsTxt, "bar.tar.gz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum != "bbbb" {
+		t.Fatalf("expected checksum %q, got %q", "bbbb", sum)
+	}
+
+	if _, err := ChecksumFor(checksumsTxt, "missing.tar.gz"); err == nil {
+		t.Fatal("expected an error for an asset not listed in checksums.txt")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("hello world")
+	sum := sha256.Sum256(data)
+	expectedHex := hex.EncodeToString(sum[:])
+
+	if err := VerifyChecksum(data, expectedHex); err != nil {
+		t.Fatalf("expected matching checksum to verify, got: %v", err)
+	}
+
+	if err := VerifyChecksum(data, "0000000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Fatal("expected a mismatched checksum to fail verification")
+	}
+}
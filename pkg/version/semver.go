@@ -0,0 +1,89 @@
+/*
+Copyright © 2019 Doppler <support@doppler.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// UpdateChannel controls how aggressively VersionsMatch treats a difference
+// between the current and latest version as worth prompting about. It is set
+// from the --update-channel flag and defaults to "minor".
+var UpdateChannel = "minor"
+
+// validUpdateChannels enumerates the only values --update-channel accepts.
+var validUpdateChannels = map[string]bool{"major": true, "minor": true, "patch": true}
+
+// NormalizeUpdateChannel validates channel against the accepted
+// --update-channel values ("major", "minor", "patch"), returning an error for
+// anything else -- a typo like "majro" must fail loudly rather than silently
+// falling back to VersionsMatch's default policy.
+func NormalizeUpdateChannel(channel string) (string, error) {
+	if !validUpdateChannels[channel] {
+		return "", fmt.Errorf("invalid update channel %q: must be one of major, minor, patch", channel)
+	}
+	return channel, nil
+}
+
+// devVersionPrefix marks a version string as a development build, e.g.
+// "v0.0.0-devel". Such versions always "match" so developers are never
+// prompted to update.
+const devVersionPrefix = "v0.0.0-devel"
+
+// VersionsMatch reports whether current and latest should be treated as the
+// same release for the purpose of update prompting, according to
+// UpdateChannel:
+//   - "major": only a major version difference is considered a mismatch
+//   - "minor" (default): a major or minor difference is a mismatch
+//   - "patch": any difference, including patch-only, is a mismatch
+//
+// A development build (current prefixed with devVersionPrefix, or
+// IsDevelopment() is true) always matches.
+func VersionsMatch(current string, latest string) bool {
+	if IsDevelopment() || strings.HasPrefix(current, devVersionPrefix) {
+		return true
+	}
+
+	current = canonicalizeVersion(current)
+	latest = canonicalizeVersion(latest)
+
+	if !semver.IsValid(current) || !semver.IsValid(latest) {
+		// can't compare; assume they match rather than risk a bad prompt
+		return true
+	}
+
+	switch UpdateChannel {
+	case "major":
+		return semver.Major(current) == semver.Major(latest)
+	case "patch":
+		return semver.Compare(current, latest) == 0
+	default:
+		return semver.MajorMinor(current) == semver.MajorMinor(latest)
+	}
+}
+
+// canonicalizeVersion ensures v has the "v" prefix golang.org/x/mod/semver
+// requires, since Doppler version strings are sometimes stored without it.
+func canonicalizeVersion(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}